@@ -0,0 +1,69 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package configs
+
+import "sync"
+
+// Event subsystem config map keys and defaults, read by pkg/events.
+const (
+	// CMEventTrackingEnabled sets the value reported by
+	// EventSystem.IsEventTrackingEnabled; AddEvent does not check it.
+	CMEventTrackingEnabled = "event.trackingEnabled"
+	// CMEventRingBufferCapacity sets the number of events retained in memory.
+	CMEventRingBufferCapacity = "event.ringBufferCapacity"
+	// CMEventRequestCapacity sets the buffered size of incoming event requests.
+	CMEventRequestCapacity = "event.requestCapacity"
+	// CMEventDurablePath enables the on-disk event log by pointing it at a
+	// directory; leaving it unset keeps the event system in-memory only.
+	CMEventDurablePath = "event.durable.path"
+	// CMEventDurableRetention is a time.ParseDuration string bounding how
+	// long durable log segments are kept before being garbage collected.
+	CMEventDurableRetention = "event.durable.retention"
+	// CMEventNDJSONHeartbeatInterval is a time.ParseDuration string setting
+	// how often StreamEventsNDJSON writes a "# heartbeat" comment line to
+	// keep an idle connection alive.
+	CMEventNDJSONHeartbeatInterval = "event.ndjson.heartbeatInterval"
+)
+
+const (
+	// DefaultEventRingBufferCapacity is used when CMEventRingBufferCapacity is unset or invalid.
+	DefaultEventRingBufferCapacity = 100000
+	// DefaultEventRequestCapacity is used when CMEventRequestCapacity is unset or invalid.
+	DefaultEventRequestCapacity = 10000
+)
+
+var (
+	configMap   = make(map[string]string)
+	configMapMu sync.RWMutex
+)
+
+// SetConfigMap replaces the active configuration map, e.g. after a config
+// reload. It is also the primary way tests exercise specific config values.
+func SetConfigMap(m map[string]string) {
+	configMapMu.Lock()
+	defer configMapMu.Unlock()
+	configMap = m
+}
+
+// GetConfigMap returns the active configuration map.
+func GetConfigMap() map[string]string {
+	configMapMu.RLock()
+	defer configMapMu.RUnlock()
+	return configMap
+}
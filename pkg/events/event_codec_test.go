@@ -0,0 +1,95 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/apache/yunikorn-core/pkg/common"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+)
+
+func TestGetEventsFromIDCompressedRoundTrips(t *testing.T) {
+	ec := createEventSystem()
+	ec.StartServiceWithPublisher(true)
+	defer ec.Stop()
+
+	for i := 0; i < 20; i++ {
+		err := ec.AddEvent(&si.EventRecord{
+			Type:        si.EventRecord_REQUEST,
+			ObjectID:    "alloc1",
+			ReferenceID: "app1",
+			Message:     strconv.Itoa(i),
+		})
+		assert.NilError(t, err)
+	}
+	err := common.WaitForCondition(time.Millisecond, time.Second, func() bool {
+		_, _, highest := ec.GetEventsFromID(0, 20)
+		return highest == 19
+	})
+	assert.NilError(t, err, "events should have been processed")
+
+	for _, codec := range []Codec{CodecNone, CodecGzip, CodecZstd} {
+		payload, lowest, highest, err := ec.GetEventsFromIDCompressed(0, 20, codec)
+		assert.NilError(t, err, "codec %v should encode without error", codec)
+		assert.Equal(t, uint64(0), lowest)
+		assert.Equal(t, uint64(19), highest)
+
+		records, err := DecodeEventsCompressed(payload, codec)
+		assert.NilError(t, err, "codec %v should decode without error", codec)
+		assert.Equal(t, 20, len(records))
+		for i, record := range records {
+			assert.Equal(t, strconv.Itoa(i), record.Message)
+		}
+	}
+}
+
+func BenchmarkGetEventsFromIDCompressed(b *testing.B) {
+	ec := createEventSystem()
+	ec.StartServiceWithPublisher(true)
+	defer ec.Stop()
+
+	const eventCount = 10000
+	for i := 0; i < eventCount; i++ {
+		_ = ec.AddEvent(&si.EventRecord{
+			Type:        si.EventRecord_REQUEST,
+			ObjectID:    "alloc-" + strconv.Itoa(i),
+			ReferenceID: "app1",
+			Message:     "allocation " + strconv.Itoa(i) + " scheduled on node-1",
+		})
+	}
+
+	for _, codec := range []Codec{CodecNone, CodecGzip, CodecZstd} {
+		b.Run(codec.String(), func(b *testing.B) {
+			var lastSize int
+			for i := 0; i < b.N; i++ {
+				payload, _, _, err := ec.GetEventsFromIDCompressed(0, eventCount, codec)
+				if err != nil {
+					b.Fatal(err)
+				}
+				lastSize = len(payload)
+			}
+			b.ReportMetric(float64(lastSize), "bytes/payload")
+		})
+	}
+}
@@ -0,0 +1,346 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+)
+
+const (
+	segmentFilePrefix  = "segment-"
+	segmentFileSuffix  = ".log"
+	defaultMaxSegBytes = 64 * 1024 * 1024
+	defaultMaxSegAge   = time.Hour
+	segmentGCInterval  = time.Minute
+	frameHeaderBytes   = 4
+)
+
+// logSegment is one append-only file covering event IDs starting at startID.
+type logSegment struct {
+	startID   uint64
+	path      string
+	file      *os.File
+	size      int64
+	createdAt time.Time
+}
+
+// durableLog is an optional, on-disk segmented event log that lets
+// EventSystemImpl survive a restart without losing history below the
+// in-memory ring buffer's lowest retained ID. It is only created when
+// configs.CMEventDurablePath is set.
+type durableLog struct {
+	sync.Mutex
+	dir         string
+	retention   time.Duration
+	maxSegBytes int64
+	maxSegAge   time.Duration
+	segments    []*logSegment
+	current     *logSegment
+	stop        chan struct{}
+}
+
+func newDurableLog(dir string, retention time.Duration) (*durableLog, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create durable event log dir %q: %w", dir, err)
+	}
+	dl := &durableLog{
+		dir:         dir,
+		retention:   retention,
+		maxSegBytes: defaultMaxSegBytes,
+		maxSegAge:   defaultMaxSegAge,
+	}
+	if err := dl.loadSegments(); err != nil {
+		return nil, err
+	}
+	return dl, nil
+}
+
+func (dl *durableLog) loadSegments() error {
+	entries, err := os.ReadDir(dl.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read durable event log dir %q: %w", dl.dir, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		startID, ok := parseSegmentFileName(entry.Name())
+		if !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		dl.segments = append(dl.segments, &logSegment{
+			startID:   startID,
+			path:      filepath.Join(dl.dir, entry.Name()),
+			size:      info.Size(),
+			createdAt: info.ModTime(),
+		})
+	}
+	sort.Slice(dl.segments, func(i, j int) bool { return dl.segments[i].startID < dl.segments[j].startID })
+	return nil
+}
+
+func segmentFileName(startID uint64) string {
+	return fmt.Sprintf("%s%020d%s", segmentFilePrefix, startID, segmentFileSuffix)
+}
+
+func parseSegmentFileName(name string) (uint64, bool) {
+	if !strings.HasPrefix(name, segmentFilePrefix) || !strings.HasSuffix(name, segmentFileSuffix) {
+		return 0, false
+	}
+	idPart := strings.TrimSuffix(strings.TrimPrefix(name, segmentFilePrefix), segmentFileSuffix)
+	startID, err := strconv.ParseUint(idPart, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return startID, true
+}
+
+// replay reads every segment in order and invokes apply for each decoded
+// event, in ID order. StartServiceWithPublisher calls it on every start to
+// restore the ring buffer and ID counter after a restart; events the ring
+// buffer already holds are skipped by eventRingBuffer.seed.
+func (dl *durableLog) replay(apply func(id uint64, event *si.EventRecord)) error {
+	dl.Lock()
+	defer dl.Unlock()
+	for _, seg := range dl.segments {
+		events, err := readSegmentFrames(seg.path)
+		if err != nil {
+			return fmt.Errorf("failed to replay durable event log segment %q: %w", seg.path, err)
+		}
+		for i, event := range events {
+			apply(seg.startID+uint64(i), event)
+		}
+	}
+	return nil
+}
+
+// Append persists event under id, rotating to a new segment first if the
+// current one has grown past its size or age limit. The outgoing segment is
+// fsync'd and closed on rotation, so a crash after rotation cannot lose
+// events written to a completed segment.
+func (dl *durableLog) Append(id uint64, event *si.EventRecord) error {
+	dl.Lock()
+	defer dl.Unlock()
+
+	if dl.current == nil || dl.current.size >= dl.maxSegBytes || time.Since(dl.current.createdAt) >= dl.maxSegAge {
+		if err := dl.rotate(id); err != nil {
+			return err
+		}
+	}
+
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event record: %w", err)
+	}
+	header := make([]byte, frameHeaderBytes)
+	binary.BigEndian.PutUint32(header, uint32(len(data)))
+	if _, err := dl.current.file.Write(header); err != nil {
+		return err
+	}
+	if _, err := dl.current.file.Write(data); err != nil {
+		return err
+	}
+	dl.current.size += int64(len(header) + len(data))
+	return nil
+}
+
+func (dl *durableLog) rotate(startID uint64) error {
+	if dl.current != nil {
+		if err := dl.current.file.Sync(); err != nil {
+			return err
+		}
+		if err := dl.current.file.Close(); err != nil {
+			return err
+		}
+	}
+	path := filepath.Join(dl.dir, segmentFileName(startID))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create durable event log segment %q: %w", path, err)
+	}
+	seg := &logSegment{startID: startID, path: path, file: file, createdAt: time.Now()}
+	dl.segments = append(dl.segments, seg)
+	dl.current = seg
+	return nil
+}
+
+// Bounds returns the lowest and highest event IDs known to the log.
+func (dl *durableLog) Bounds() (lowest uint64, highest uint64, ok bool) {
+	dl.Lock()
+	defer dl.Unlock()
+	if len(dl.segments) == 0 {
+		return 0, 0, false
+	}
+	lowest = dl.segments[0].startID
+	last := dl.segments[len(dl.segments)-1]
+	events, err := readSegmentFrames(last.path)
+	if err != nil || len(events) == 0 {
+		return lowest, lowest, true
+	}
+	highest = last.startID + uint64(len(events)) - 1
+	return lowest, highest, true
+}
+
+// ReadFrom returns up to count events starting at id, reading across
+// segment boundaries as needed.
+func (dl *durableLog) ReadFrom(id uint64, count uint64) ([]*si.EventRecord, error) {
+	dl.Lock()
+	segments := make([]*logSegment, len(dl.segments))
+	copy(segments, dl.segments)
+	dl.Unlock()
+
+	var collected []*si.EventRecord
+	for _, seg := range segments {
+		if uint64(len(collected)) >= count {
+			break
+		}
+		events, err := readSegmentFrames(seg.path)
+		if err != nil {
+			return nil, err
+		}
+		segHighest := seg.startID + uint64(len(events))
+		if id >= segHighest {
+			continue
+		}
+		start := uint64(0)
+		if id > seg.startID {
+			start = id - seg.startID
+		}
+		for _, event := range events[start:] {
+			collected = append(collected, event)
+			if uint64(len(collected)) >= count {
+				break
+			}
+		}
+	}
+	return collected, nil
+}
+
+func readSegmentFrames(path string) ([]*si.EventRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var events []*si.EventRecord
+	header := make([]byte, frameHeaderBytes)
+	for {
+		if _, err := io.ReadFull(file, header); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		length := binary.BigEndian.Uint32(header)
+		data := make([]byte, length)
+		if _, err := io.ReadFull(file, data); err != nil {
+			return nil, err
+		}
+		event := &si.EventRecord{}
+		if err := proto.Unmarshal(data, event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// StartGC launches the background goroutine that removes segments older
+// than the configured retention, always keeping the current (write) segment.
+func (dl *durableLog) StartGC() {
+	dl.stop = make(chan struct{})
+	go dl.runGC()
+}
+
+func (dl *durableLog) StopGC() {
+	if dl.stop != nil {
+		close(dl.stop)
+	}
+}
+
+// Close syncs and closes the current write segment. It must be called when
+// the owning EventSystemImpl stops, otherwise the fd for the active segment
+// leaks across a Stop()/StartService() restart cycle.
+func (dl *durableLog) Close() error {
+	dl.Lock()
+	defer dl.Unlock()
+	if dl.current == nil {
+		return nil
+	}
+	if err := dl.current.file.Sync(); err != nil {
+		return err
+	}
+	err := dl.current.file.Close()
+	dl.current = nil
+	return err
+}
+
+func (dl *durableLog) runGC() {
+	ticker := time.NewTicker(segmentGCInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-dl.stop:
+			return
+		case <-ticker.C:
+			dl.gcOnce()
+		}
+	}
+}
+
+func (dl *durableLog) gcOnce() {
+	if dl.retention <= 0 {
+		return
+	}
+	dl.Lock()
+	defer dl.Unlock()
+	cutoff := time.Now().Add(-dl.retention)
+	kept := dl.segments[:0]
+	for _, seg := range dl.segments {
+		if seg == dl.current || seg.createdAt.After(cutoff) {
+			kept = append(kept, seg)
+			continue
+		}
+		if err := os.Remove(seg.path); err != nil && !os.IsNotExist(err) {
+			kept = append(kept, seg)
+		}
+	}
+	dl.segments = kept
+}
@@ -0,0 +1,133 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+)
+
+// EventFilter declares which events a subscriber of an EventStream wants to
+// receive. A zero-value EventFilter matches every event, preserving the
+// behavior of CreateEventStream. All non-empty fields are ANDed together;
+// Types and ChangeDetails are OR-matched within themselves.
+type EventFilter struct {
+	// Types restricts delivery to the given EventRecord_Type values. Empty
+	// matches all types.
+	Types []si.EventRecord_Type
+
+	// ObjectIDGlob restricts delivery to events whose ObjectID matches the
+	// given filepath.Match glob (e.g. "alloc-*"). Empty matches all.
+	ObjectIDGlob string
+
+	// ReferenceID restricts delivery to events with an exact ReferenceID
+	// match. Empty matches all.
+	ReferenceID string
+
+	// ChangeDetails restricts delivery to events whose (EventChangeType,
+	// EventChangeDetail) pair is present in this slice. Empty matches all.
+	ChangeDetails []EventChangeFilter
+}
+
+// EventChangeFilter pairs an EventChangeType with an EventChangeDetail for
+// use in EventFilter.ChangeDetails.
+type EventChangeFilter struct {
+	ChangeType   si.EventRecord_ChangeType
+	ChangeDetail si.EventRecord_ChangeDetail
+}
+
+// compiledEventFilter is the validated, ready-to-evaluate form of an
+// EventFilter, stored on the EventStream so that publish-time matching does
+// not re-validate the filter for every event.
+type compiledEventFilter struct {
+	matchAll      bool
+	types         map[si.EventRecord_Type]bool
+	objectIDGlob  string
+	referenceID   string
+	changeDetails map[EventChangeFilter]bool
+}
+
+// compile validates filter and returns its compiled form. An error is
+// returned instead of silently matching everything when the filter is
+// malformed, e.g. an invalid glob pattern.
+func (f EventFilter) compile() (*compiledEventFilter, error) {
+	if len(f.Types) == 0 && f.ObjectIDGlob == "" && f.ReferenceID == "" && len(f.ChangeDetails) == 0 {
+		return &compiledEventFilter{matchAll: true}, nil
+	}
+
+	compiled := &compiledEventFilter{
+		objectIDGlob: f.ObjectIDGlob,
+		referenceID:  f.ReferenceID,
+	}
+
+	if f.ObjectIDGlob != "" {
+		if _, err := filepath.Match(f.ObjectIDGlob, ""); err != nil {
+			return nil, fmt.Errorf("invalid ObjectID glob %q: %w", f.ObjectIDGlob, err)
+		}
+	}
+
+	if len(f.Types) > 0 {
+		compiled.types = make(map[si.EventRecord_Type]bool)
+		for _, t := range f.Types {
+			compiled.types[t] = true
+		}
+	}
+
+	if len(f.ChangeDetails) > 0 {
+		compiled.changeDetails = make(map[EventChangeFilter]bool)
+		for _, cd := range f.ChangeDetails {
+			compiled.changeDetails[cd] = true
+		}
+	}
+
+	return compiled, nil
+}
+
+// matches reports whether event satisfies the compiled filter.
+func (c *compiledEventFilter) matches(event *si.EventRecord) bool {
+	if c.matchAll || event == nil {
+		return c.matchAll
+	}
+
+	if c.types != nil && !c.types[event.Type] {
+		return false
+	}
+
+	if c.objectIDGlob != "" {
+		ok, err := filepath.Match(c.objectIDGlob, event.ObjectID)
+		if err != nil || !ok {
+			return false
+		}
+	}
+
+	if c.referenceID != "" && c.referenceID != event.ReferenceID {
+		return false
+	}
+
+	if c.changeDetails != nil {
+		key := EventChangeFilter{ChangeType: event.EventChangeType, ChangeDetail: event.EventChangeDetail}
+		if !c.changeDetails[key] {
+			return false
+		}
+	}
+
+	return true
+}
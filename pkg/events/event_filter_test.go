@@ -0,0 +1,107 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/apache/yunikorn-core/pkg/common"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+)
+
+func TestFilteredEventStreamOnlyObservesMatchingType(t *testing.T) {
+	Init()
+	eventSystem, ok := GetEventSystem().(*EventSystemImpl)
+	if !ok {
+		t.Fatal("failed to cast GetEventSystem() to *EventSystemImpl")
+	}
+	eventSystem.StartService()
+	defer eventSystem.Stop()
+
+	stream, err := eventSystem.CreateFilteredEventStream("request-only", 10, EventFilter{
+		Types: []si.EventRecord_Type{si.EventRecord_REQUEST},
+	})
+	assert.NilError(t, err, "valid filter should compile")
+	defer eventSystem.RemoveStream(stream)
+
+	eventSystem.AddEvent(&si.EventRecord{Type: si.EventRecord_NODE, ObjectID: "node1"})
+	eventSystem.AddEvent(&si.EventRecord{Type: si.EventRecord_REQUEST, ObjectID: "alloc1", ReferenceID: "app1"})
+
+	var received StreamedEvent
+	err = common.WaitForCondition(time.Millisecond, time.Second, func() bool {
+		select {
+		case received = <-stream.Channel:
+			return true
+		default:
+			return false
+		}
+	})
+	assert.NilError(t, err, "the REQUEST event should have been delivered")
+	assert.Equal(t, received.Event.Type, si.EventRecord_REQUEST)
+	assert.Equal(t, received.Event.ObjectID, "alloc1")
+
+	select {
+	case unexpected := <-stream.Channel:
+		t.Fatalf("stream should not have observed a NODE event, got %v", unexpected)
+	default:
+	}
+}
+
+func TestInvalidFilterReturnsError(t *testing.T) {
+	Init()
+	eventSystem, ok := GetEventSystem().(*EventSystemImpl)
+	if !ok {
+		t.Fatal("failed to cast GetEventSystem() to *EventSystemImpl")
+	}
+	eventSystem.StartService()
+	defer eventSystem.Stop()
+
+	_, err := eventSystem.CreateFilteredEventStream("bad-glob", 10, EventFilter{
+		ObjectIDGlob: "[",
+	})
+	assert.ErrorContains(t, err, "invalid ObjectID glob")
+}
+
+func TestCreateEventStreamMatchesAll(t *testing.T) {
+	Init()
+	eventSystem, ok := GetEventSystem().(*EventSystemImpl)
+	if !ok {
+		t.Fatal("failed to cast GetEventSystem() to *EventSystemImpl")
+	}
+	eventSystem.StartService()
+	defer eventSystem.Stop()
+
+	stream := eventSystem.CreateEventStream("match-all", 10)
+	defer eventSystem.RemoveStream(stream)
+
+	eventSystem.AddEvent(&si.EventRecord{Type: si.EventRecord_NODE, ObjectID: "node1"})
+
+	err := common.WaitForCondition(time.Millisecond, time.Second, func() bool {
+		select {
+		case <-stream.Channel:
+			return true
+		default:
+			return false
+		}
+	})
+	assert.NilError(t, err, "the NODE event should have been delivered to an unfiltered stream")
+}
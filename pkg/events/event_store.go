@@ -0,0 +1,64 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+import (
+	"sync"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+)
+
+// eventStore is a staging area for events that have been accepted by
+// AddEvent but not yet handed off to the ring buffer and active streams by
+// the publisher. Draining it is idempotent: CollectEvents empties it and
+// hands the caller ownership of the collected slice.
+type eventStore struct {
+	sync.Mutex
+	events []*si.EventRecord
+}
+
+func newEventStore() *eventStore {
+	return &eventStore{
+		events: make([]*si.EventRecord, 0),
+	}
+}
+
+func (es *eventStore) Add(event *si.EventRecord) {
+	es.Lock()
+	defer es.Unlock()
+	es.events = append(es.events, event)
+}
+
+func (es *eventStore) CountStoredEvents() int {
+	es.Lock()
+	defer es.Unlock()
+	return len(es.events)
+}
+
+// CollectEvents drains and returns all events currently staged in the store.
+func (es *eventStore) CollectEvents() []*si.EventRecord {
+	es.Lock()
+	defer es.Unlock()
+	if len(es.events) == 0 {
+		return nil
+	}
+	collected := es.events
+	es.events = make([]*si.EventRecord, 0)
+	return collected
+}
@@ -0,0 +1,61 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+import (
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+)
+
+// StreamedEvent pairs an event with the ring buffer ID it was assigned when
+// published, so a consumer that also replays backlog (e.g.
+// StreamEventsNDJSON) can tell which live events it has already seen via
+// replay and dedupe accordingly.
+type StreamedEvent struct {
+	ID    uint64
+	Event *si.EventRecord
+}
+
+// EventStream is a live subscription handed out by CreateEventStream or
+// CreateFilteredEventStream. The publisher writes every event that passes
+// the compiled filter into Channel; the subscriber is responsible for
+// draining it and for calling RemoveStream when done. ID and Event always
+// arrive together as a single StreamedEvent, so a dropped send under a full
+// buffer can never desynchronize one half from the other.
+type EventStream struct {
+	Name    string
+	Channel chan StreamedEvent
+
+	id     uint64
+	filter *compiledEventFilter
+}
+
+// accepts reports whether event should be delivered to this stream.
+func (s *EventStream) accepts(event *si.EventRecord) bool {
+	return s.filter.matches(event)
+}
+
+// publish attempts a non-blocking send of id and event to the stream. A
+// full channel drops the event rather than stalling the publisher; slow
+// consumers lose events instead of backpressuring the whole system.
+func (s *EventStream) publish(id uint64, event *si.EventRecord) {
+	select {
+	case s.Channel <- StreamedEvent{ID: id, Event: event}:
+	default:
+	}
+}
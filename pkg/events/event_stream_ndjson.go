@@ -0,0 +1,123 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/common/configs"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+)
+
+const defaultNDJSONHeartbeatInterval = 15 * time.Second
+
+// flusher is satisfied by http.ResponseWriter and bufio.Writer among others;
+// declared locally so this package does not need to import net/http.
+type flusher interface {
+	Flush()
+}
+
+// StreamEventsNDJSON registers an internal stream filtered by filter and
+// writes every event it receives to w as newline-delimited JSON, flushing
+// after each record so idle connections carry no buffered data. If
+// lastEventID is non-zero, the ring buffer is replayed from lastEventID+1
+// before switching to the live stream, so a client reconnecting after a
+// transient disconnect does not miss events emitted in between. The stream
+// is registered before the backlog is read so no event is lost in between,
+// which means some backlog events may also arrive on the live channel;
+// those are recognized by ID and dropped so the client never sees a record
+// twice. The method blocks until ctx is cancelled or a write to w fails,
+// and always removes its stream before returning.
+func (ec *EventSystemImpl) StreamEventsNDJSON(ctx context.Context, w io.Writer, filter EventFilter, lastEventID uint64) error {
+	stream, err := ec.CreateFilteredEventStream("ndjson-tail", int(getRequestCapacity()), filter)
+	if err != nil {
+		return err
+	}
+	defer ec.RemoveStream(stream)
+
+	lastDelivered := lastEventID
+	if lastEventID > 0 {
+		backlog, _, highest := ec.GetEventsFromID(lastEventID+1, getRingBufferCapacity())
+		for _, event := range backlog {
+			if err := writeNDJSONRecord(w, event); err != nil {
+				return err
+			}
+		}
+		if len(backlog) > 0 {
+			lastDelivered = highest
+		}
+	}
+
+	heartbeat := time.NewTicker(getNDJSONHeartbeatInterval())
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case streamed := <-stream.Channel:
+			if streamed.ID <= lastDelivered {
+				continue
+			}
+			if err := writeNDJSONRecord(w, streamed.Event); err != nil {
+				return err
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, "# heartbeat\n"); err != nil {
+				return err
+			}
+			if f, ok := w.(flusher); ok {
+				f.Flush()
+			}
+		}
+	}
+}
+
+func writeNDJSONRecord(w io.Writer, event *si.EventRecord) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event record as JSON: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return err
+	}
+	if f, ok := w.(flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+func getNDJSONHeartbeatInterval() time.Duration {
+	value, ok := configs.GetConfigMap()[configs.CMEventNDJSONHeartbeatInterval]
+	if !ok {
+		return defaultNDJSONHeartbeatInterval
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		return defaultNDJSONHeartbeatInterval
+	}
+	return interval
+}
@@ -0,0 +1,207 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/apache/yunikorn-core/pkg/common"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent writer/reader access, as
+// StreamEventsNDJSON writes from a goroutine while the test reads lines.
+type syncBuffer struct {
+	sync.Mutex
+	bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return b.Buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+	return b.Buffer.String()
+}
+
+func TestNDJSONStreamFramingAndFiltering(t *testing.T) {
+	ec := createEventSystem()
+	ec.StartService()
+	defer ec.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var appOut, allOut syncBuffer
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_ = ec.StreamEventsNDJSON(ctx, &appOut, EventFilter{Types: []si.EventRecord_Type{si.EventRecord_APP}}, 0)
+	}()
+	go func() {
+		defer wg.Done()
+		_ = ec.StreamEventsNDJSON(ctx, &allOut, EventFilter{}, 0)
+	}()
+
+	// give both streams a moment to register before publishing
+	err := common.WaitForCondition(time.Millisecond, time.Second, func() bool {
+		return len(ec.GetEventStreams()) == 2
+	})
+	assert.NilError(t, err, "both streams should have registered")
+
+	assert.NilError(t, ec.AddEvent(&si.EventRecord{Type: si.EventRecord_NODE, ObjectID: "node1"}))
+	assert.NilError(t, ec.AddEvent(&si.EventRecord{Type: si.EventRecord_APP, ObjectID: "app1"}))
+
+	err = common.WaitForCondition(time.Millisecond, time.Second, func() bool {
+		return strings.Count(allOut.String(), "\n") >= 2
+	})
+	assert.NilError(t, err, "the unfiltered stream should observe both events")
+
+	cancel()
+	wg.Wait()
+
+	allLines := splitNDJSONLines(allOut.String())
+	assert.Equal(t, 2, len(allLines))
+	var nodeRecord, appRecord si.EventRecord
+	assert.NilError(t, json.Unmarshal([]byte(allLines[0]), &nodeRecord))
+	assert.NilError(t, json.Unmarshal([]byte(allLines[1]), &appRecord))
+	assert.Equal(t, si.EventRecord_NODE, nodeRecord.Type)
+	assert.Equal(t, si.EventRecord_APP, appRecord.Type)
+
+	appLines := splitNDJSONLines(appOut.String())
+	assert.Equal(t, 1, len(appLines))
+	var filtered si.EventRecord
+	assert.NilError(t, json.Unmarshal([]byte(appLines[0]), &filtered))
+	assert.Equal(t, si.EventRecord_APP, filtered.Type)
+}
+
+func TestNDJSONStreamResumesFromLastEventID(t *testing.T) {
+	ec := createEventSystem()
+	ec.StartService()
+
+	// ids 0..4
+	for i := 0; i < 5; i++ {
+		assert.NilError(t, ec.AddEvent(&si.EventRecord{Type: si.EventRecord_NODE, ObjectID: "node1"}))
+	}
+	err := common.WaitForCondition(time.Millisecond, time.Second, func() bool {
+		_, _, highest := ec.GetEventsFromID(0, 5)
+		return highest == 4
+	})
+	assert.NilError(t, err, "the first five events should be in the ring buffer")
+
+	// client last saw id 2, so it should only receive the replayed ids 3 and 4
+	// before the live tail picks up id 5.
+	ctx, cancel := context.WithCancel(context.Background())
+	var out syncBuffer
+	done := make(chan struct{})
+	go func() {
+		_ = ec.StreamEventsNDJSON(ctx, &out, EventFilter{}, 2)
+		close(done)
+	}()
+
+	err = common.WaitForCondition(time.Millisecond, time.Second, func() bool {
+		return strings.Count(out.String(), "\n") >= 2
+	})
+	assert.NilError(t, err, "resuming from lastEventID 2 should replay the remaining backlog")
+
+	assert.NilError(t, ec.AddEvent(&si.EventRecord{Type: si.EventRecord_NODE, ObjectID: "node1"}))
+	err = common.WaitForCondition(time.Millisecond, time.Second, func() bool {
+		return strings.Count(out.String(), "\n") >= 3
+	})
+	assert.NilError(t, err, "the live tail should pick up events added after the stream started")
+
+	cancel()
+	<-done
+	ec.Stop()
+}
+
+// TestNDJSONStreamResumeDoesNotDuplicateConcurrentEvents guards against a
+// race where an event added between stream registration and backlog replay
+// would be delivered twice: once via the backlog read (it is already in the
+// ring buffer by then) and once via the live channel (the stream was
+// already registered when it was published).
+func TestNDJSONStreamResumeDoesNotDuplicateConcurrentEvents(t *testing.T) {
+	ec := createEventSystem()
+	ec.StartService()
+
+	// ids 0..2
+	for i := 0; i < 3; i++ {
+		assert.NilError(t, ec.AddEvent(&si.EventRecord{Type: si.EventRecord_NODE, ObjectID: "node1"}))
+	}
+	err := common.WaitForCondition(time.Millisecond, time.Second, func() bool {
+		_, _, highest := ec.GetEventsFromID(0, 3)
+		return highest == 2
+	})
+	assert.NilError(t, err, "the first three events should be in the ring buffer")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var out syncBuffer
+	done := make(chan struct{})
+	go func() {
+		// resume from id 1, so id 2 is expected from the backlog
+		_ = ec.StreamEventsNDJSON(ctx, &out, EventFilter{}, 1)
+		close(done)
+	}()
+
+	// publish two more events (ids 3, 4) concurrently with the backlog
+	// replay window; these must appear exactly once each in out, never
+	// twice, regardless of whether drainStore races ahead of GetEventsFromID.
+	assert.NilError(t, ec.AddEvent(&si.EventRecord{Type: si.EventRecord_NODE, ObjectID: "node1"}))
+	assert.NilError(t, ec.AddEvent(&si.EventRecord{Type: si.EventRecord_NODE, ObjectID: "node1"}))
+
+	err = common.WaitForCondition(time.Millisecond, time.Second, func() bool {
+		return strings.Count(out.String(), "\n") >= 3
+	})
+	assert.NilError(t, err, "the backlog and the two concurrent events should all be delivered")
+
+	// give any duplicate delivery a chance to land before asserting the count
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+	ec.Stop()
+
+	assert.Equal(t, 3, len(splitNDJSONLines(out.String())))
+}
+
+func splitNDJSONLines(output string) []string {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
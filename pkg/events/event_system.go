@@ -0,0 +1,418 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/apache/yunikorn-core/pkg/common/configs"
+	"github.com/apache/yunikorn-core/pkg/log"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+)
+
+const configPollInterval = time.Second
+
+// EventSystem is the scheduler-wide event bus. Events are accepted via
+// AddEvent, kept in a bounded ring buffer addressable by GetEventsFromID and,
+// when a publisher is running, fanned out to any number of live
+// EventStream subscribers.
+type EventSystem interface {
+	AddEvent(event *si.EventRecord) error
+	RegisterValidator(t si.EventRecord_Type, validator EventValidator)
+	StartService()
+	StartServiceWithPublisher(withPublisher bool)
+	Stop()
+	GetEventsFromID(id uint64, count uint64) (collected []*si.EventRecord, lowest uint64, highest uint64)
+	GetEventsFromIDCompressed(startID uint64, count uint64, codec Codec) (payload []byte, lowest uint64, highest uint64, err error)
+	StreamEventsNDJSON(ctx context.Context, w io.Writer, filter EventFilter, lastEventID uint64) error
+	IsEventTrackingEnabled() bool
+	GetRingBufferCapacity() uint64
+	GetRequestCapacity() uint64
+	CreateEventStream(name string, bufSize int) *EventStream
+	CreateFilteredEventStream(name string, bufSize int, filter EventFilter) (*EventStream, error)
+	RemoveStream(stream *EventStream)
+	GetEventStreams() []*EventStream
+}
+
+// EventSystemImpl is the default EventSystem implementation.
+type EventSystemImpl struct {
+	Store       *eventStore
+	eventBuffer *eventRingBuffer
+
+	channel chan *si.EventRecord
+	stop    chan struct{}
+
+	durableLog *durableLog
+	validators *validatorRegistry
+
+	trackingEnabled bool
+	requestCapacity uint64
+
+	streams  []*EventStream
+	streamID uint64
+
+	running   bool
+	publisher bool
+
+	sync.RWMutex
+}
+
+var ev EventSystem
+var once sync.Once
+
+// Init creates the singleton EventSystem. It is safe to call multiple times;
+// only the first call has an effect.
+func Init() {
+	once.Do(func() {
+		ev = createEventSystem()
+	})
+}
+
+// GetEventSystem returns the singleton EventSystem created by Init.
+func GetEventSystem() EventSystem {
+	return ev
+}
+
+func createEventSystem() *EventSystemImpl {
+	dl, err := newDurableLog(configs.GetConfigMap()[configs.CMEventDurablePath], getDurableRetention())
+	if err != nil {
+		log.Log(log.SchedFSM).Error("failed to open durable event log, falling back to in-memory only", zap.Error(err))
+		dl = nil
+	}
+	return &EventSystemImpl{
+		Store:           newEventStore(),
+		eventBuffer:     newEventRingBuffer(getRingBufferCapacity()),
+		channel:         make(chan *si.EventRecord, getEventChannelCapacity()),
+		durableLog:      dl,
+		validators:      newDefaultValidatorRegistry(),
+		trackingEnabled: configs.GetConfigMap()[configs.CMEventTrackingEnabled] != "false",
+		requestCapacity: getRequestCapacity(),
+		streams:         make([]*EventStream, 0),
+	}
+}
+
+func getDurableRetention() time.Duration {
+	value, ok := configs.GetConfigMap()[configs.CMEventDurableRetention]
+	if !ok {
+		return 0
+	}
+	retention, err := time.ParseDuration(value)
+	if err != nil {
+		log.Log(log.SchedFSM).Warn("invalid durable event log retention, disabling GC", zap.String("value", value))
+		return 0
+	}
+	return retention
+}
+
+func getEventChannelCapacity() uint64 {
+	return getRingBufferCapacity()
+}
+
+// StartService starts the event system with the publisher enabled, i.e.
+// events are both buffered and fanned out to registered streams.
+func (ec *EventSystemImpl) StartService() {
+	ec.StartServiceWithPublisher(true)
+}
+
+// StartServiceWithPublisher starts the background processing loop. When
+// withPublisher is false, accepted events are only staged in Store; they are
+// moved into the ring buffer and fanned out to streams only when
+// GetEventsFromID drains the store - this is used by tests that need to
+// inspect events before they are consumed.
+func (ec *EventSystemImpl) StartServiceWithPublisher(withPublisher bool) {
+	ec.Lock()
+	defer ec.Unlock()
+	if ec.running {
+		return
+	}
+	if ec.durableLog != nil {
+		if err := ec.durableLog.replay(func(id uint64, event *si.EventRecord) {
+			ec.eventBuffer.seed(id, event)
+		}); err != nil {
+			log.Log(log.SchedFSM).Error("failed to replay durable event log", zap.Error(err))
+		}
+		ec.durableLog.StartGC()
+	}
+
+	ec.running = true
+	ec.publisher = withPublisher
+	ec.stop = make(chan struct{})
+
+	go ec.handleEvents()
+	go ec.watchConfig()
+}
+
+// Stop terminates the processing loop. It is safe to call AddEvent after
+// Stop returns; events are simply dropped.
+func (ec *EventSystemImpl) Stop() {
+	ec.Lock()
+	defer ec.Unlock()
+	if !ec.running {
+		return
+	}
+	ec.running = false
+	close(ec.stop)
+	if ec.durableLog != nil {
+		ec.durableLog.StopGC()
+		if err := ec.durableLog.Close(); err != nil {
+			log.Log(log.SchedFSM).Error("failed to close durable event log segment", zap.Error(err))
+		}
+	}
+}
+
+// AddEvent enqueues event for asynchronous processing. A nil event is a
+// no-op. If a validator is registered for event.Type and rejects it, AddEvent
+// returns that error instead of queuing the event, and increments
+// yunikorn_events_rejected_total{type,reason}; this check runs whether or not
+// the service is running. A valid event passed before StartService or after
+// Stop is dropped without an error.
+func (ec *EventSystemImpl) AddEvent(event *si.EventRecord) error {
+	if event == nil {
+		return nil
+	}
+	if err := ec.validators.validate(event); err != nil {
+		eventsRejectedTotal.WithLabelValues(event.Type.String(), validationReason(err)).Inc()
+		return err
+	}
+	ec.RLock()
+	running := ec.running
+	ec.RUnlock()
+	if !running {
+		return nil
+	}
+	select {
+	case ec.channel <- event:
+	default:
+		log.Log(log.SchedFSM).Warn("event channel is full, dropping event")
+	}
+	return nil
+}
+
+func validationReason(err error) string {
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		return verr.Reason
+	}
+	return "unknown"
+}
+
+// RegisterValidator installs validator as the guard for EventRecord_Type t,
+// replacing whatever default or previously registered validator handled it.
+func (ec *EventSystemImpl) RegisterValidator(t si.EventRecord_Type, validator EventValidator) {
+	ec.validators.register(t, validator)
+}
+
+func (ec *EventSystemImpl) handleEvents() {
+	for {
+		select {
+		case <-ec.stop:
+			return
+		case event := <-ec.channel:
+			ec.Store.Add(event)
+			ec.RLock()
+			publish := ec.publisher
+			ec.RUnlock()
+			if publish {
+				ec.drainStore()
+			}
+		}
+	}
+}
+
+// drainStore moves every event currently staged in Store into the ring
+// buffer and fans each one out to the streams whose filter accepts it.
+func (ec *EventSystemImpl) drainStore() {
+	events := ec.Store.CollectEvents()
+	for _, event := range events {
+		id := ec.eventBuffer.Add(event)
+		if ec.durableLog != nil {
+			if err := ec.durableLog.Append(id, event); err != nil {
+				log.Log(log.SchedFSM).Error("failed to append event to durable log", zap.Error(err))
+			}
+		}
+		ec.RLock()
+		streams := ec.streams
+		ec.RUnlock()
+		for _, stream := range streams {
+			if stream.accepts(event) {
+				stream.publish(id, event)
+			}
+		}
+	}
+}
+
+func (ec *EventSystemImpl) watchConfig() {
+	ticker := time.NewTicker(configPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ec.stop:
+			return
+		case <-ticker.C:
+			ec.refreshConfig()
+		}
+	}
+}
+
+func (ec *EventSystemImpl) refreshConfig() {
+	ec.Lock()
+	defer ec.Unlock()
+	ec.trackingEnabled = configs.GetConfigMap()[configs.CMEventTrackingEnabled] != "false"
+	ec.requestCapacity = getRequestCapacity()
+	ec.eventBuffer.Resize(getRingBufferCapacity())
+}
+
+// GetEventsFromID flushes any events still staged in Store (so that it
+// behaves consistently regardless of whether a publisher is running) and
+// returns up to count events starting at id, plus the lowest and highest
+// IDs known to the system. If id falls below what the in-memory ring
+// buffer retains, the durable log (when enabled) is consulted instead of
+// reporting those IDs as unavailable.
+func (ec *EventSystemImpl) GetEventsFromID(id uint64, count uint64) ([]*si.EventRecord, uint64, uint64) {
+	ec.drainStore()
+	collected, lowest, highest := ec.eventBuffer.GetEventsFromID(id, count)
+
+	if ec.durableLog == nil {
+		return collected, lowest, highest
+	}
+	memLowest := lowest
+
+	diskLowest, diskHighest, ok := ec.durableLog.Bounds()
+	if !ok {
+		return collected, lowest, highest
+	}
+	if diskLowest < lowest {
+		lowest = diskLowest
+	}
+	if diskHighest > highest {
+		highest = diskHighest
+	}
+	if id < memLowest || len(collected) == 0 {
+		fromDisk, err := ec.durableLog.ReadFrom(id, count)
+		if err != nil {
+			log.Log(log.SchedFSM).Error("failed to read events from durable log", zap.Error(err))
+			return collected, lowest, highest
+		}
+		if len(fromDisk) > 0 {
+			return fromDisk, lowest, highest
+		}
+	}
+	return collected, lowest, highest
+}
+
+func (ec *EventSystemImpl) IsEventTrackingEnabled() bool {
+	ec.RLock()
+	defer ec.RUnlock()
+	return ec.trackingEnabled
+}
+
+func (ec *EventSystemImpl) GetRingBufferCapacity() uint64 {
+	ec.RLock()
+	defer ec.RUnlock()
+	return ec.eventBuffer.capacity
+}
+
+func (ec *EventSystemImpl) GetRequestCapacity() uint64 {
+	ec.RLock()
+	defer ec.RUnlock()
+	return ec.requestCapacity
+}
+
+// CreateEventStream registers a stream that receives every event, preserving
+// the pre-filter behavior.
+func (ec *EventSystemImpl) CreateEventStream(name string, bufSize int) *EventStream {
+	stream, err := ec.CreateFilteredEventStream(name, bufSize, EventFilter{})
+	if err != nil {
+		// EventFilter{} always compiles; this would indicate a programming error.
+		log.Log(log.SchedFSM).Error("unexpected error compiling match-all filter")
+		return nil
+	}
+	return stream
+}
+
+// CreateFilteredEventStream registers a stream that only receives events
+// matching filter. An error is returned if filter cannot be compiled instead
+// of silently falling back to matching everything.
+func (ec *EventSystemImpl) CreateFilteredEventStream(name string, bufSize int, filter EventFilter) (*EventStream, error) {
+	compiled, err := filter.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	ec.Lock()
+	defer ec.Unlock()
+	stream := &EventStream{
+		Name:    name,
+		Channel: make(chan StreamedEvent, bufSize),
+		id:      ec.streamID,
+		filter:  compiled,
+	}
+	ec.streamID++
+	ec.streams = append(ec.streams, stream)
+	return stream, nil
+}
+
+// RemoveStream unregisters a stream previously returned by
+// CreateEventStream/CreateFilteredEventStream.
+func (ec *EventSystemImpl) RemoveStream(stream *EventStream) {
+	ec.Lock()
+	defer ec.Unlock()
+	for i, s := range ec.streams {
+		if s == stream {
+			ec.streams = append(ec.streams[:i], ec.streams[i+1:]...)
+			return
+		}
+	}
+}
+
+// GetEventStreams returns a snapshot of the currently registered streams.
+func (ec *EventSystemImpl) GetEventStreams() []*EventStream {
+	ec.RLock()
+	defer ec.RUnlock()
+	streams := make([]*EventStream, len(ec.streams))
+	copy(streams, ec.streams)
+	return streams
+}
+
+func getRequestCapacity() uint64 {
+	return parseUintConfig(configs.CMEventRequestCapacity, uint64(configs.DefaultEventRequestCapacity))
+}
+
+func getRingBufferCapacity() uint64 {
+	return parseUintConfig(configs.CMEventRingBufferCapacity, uint64(configs.DefaultEventRingBufferCapacity))
+}
+
+func parseUintConfig(key string, fallback uint64) uint64 {
+	value, ok := configs.GetConfigMap()[key]
+	if !ok {
+		return fallback
+	}
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || parsed == 0 {
+		return fallback
+	}
+	return parsed
+}
@@ -0,0 +1,150 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+)
+
+var eventsRejectedTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "yunikorn_events_rejected_total",
+		Help: "Total number of event records rejected by AddEvent, by record type and rejection reason",
+	},
+	[]string{"type", "reason"},
+)
+
+func init() {
+	prometheus.MustRegister(eventsRejectedTotal)
+}
+
+// EventValidator checks whether an si.EventRecord of the type it is
+// registered for is well-formed. AddEvent rejects any event for which the
+// registered validator returns a non-nil error; types with no registered
+// validator pass through unchecked.
+type EventValidator interface {
+	Validate(event *si.EventRecord) error
+}
+
+// ValidationError carries a stable, low-cardinality reason code alongside
+// the human-readable error, so that AddEvent can label the
+// yunikorn_events_rejected_total metric without exploding its cardinality
+// on free-form error text.
+type ValidationError struct {
+	Reason string
+	Err    error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// validatorRegistry maps an EventRecord_Type to the EventValidator guarding
+// it and is held per EventSystemImpl so shims and plugins can tighten or
+// replace the defaults via RegisterValidator without affecting other
+// EventSystem instances (e.g. in tests).
+type validatorRegistry struct {
+	sync.RWMutex
+	validators map[si.EventRecord_Type]EventValidator
+}
+
+func newDefaultValidatorRegistry() *validatorRegistry {
+	reg := &validatorRegistry{validators: make(map[si.EventRecord_Type]EventValidator)}
+	reg.register(si.EventRecord_REQUEST, &requestEventValidator{})
+	reg.register(si.EventRecord_NODE, &nodeEventValidator{})
+	return reg
+}
+
+func (r *validatorRegistry) register(t si.EventRecord_Type, v EventValidator) {
+	r.Lock()
+	defer r.Unlock()
+	r.validators[t] = v
+}
+
+func (r *validatorRegistry) validate(event *si.EventRecord) error {
+	r.RLock()
+	v, ok := r.validators[event.Type]
+	r.RUnlock()
+	if !ok {
+		return nil
+	}
+	return v.Validate(event)
+}
+
+// requestEventValidator enforces the invariants AddEvent expects of REQUEST
+// events: both ObjectID and ReferenceID must be set. allowedChangeDetails
+// can be narrowed by registering a stricter replacement validator; an empty
+// slice (the default) allows any EventChangeType/EventChangeDetail pair.
+type requestEventValidator struct {
+	allowedChangeDetails []EventChangeFilter
+}
+
+func (v *requestEventValidator) Validate(event *si.EventRecord) error {
+	if event.ObjectID == "" {
+		return &ValidationError{Reason: "missing_object_id", Err: fmt.Errorf("REQUEST event is missing ObjectID")}
+	}
+	if event.ReferenceID == "" {
+		return &ValidationError{Reason: "missing_reference_id", Err: fmt.Errorf("REQUEST event is missing ReferenceID")}
+	}
+	if len(v.allowedChangeDetails) == 0 {
+		return nil
+	}
+	key := EventChangeFilter{ChangeType: event.EventChangeType, ChangeDetail: event.EventChangeDetail}
+	for _, allowed := range v.allowedChangeDetails {
+		if allowed == key {
+			return nil
+		}
+	}
+	return &ValidationError{
+		Reason: "unsupported_change_detail",
+		Err:    fmt.Errorf("REQUEST event has an unsupported EventChangeType/EventChangeDetail combination: %v/%v", event.EventChangeType, event.EventChangeDetail),
+	}
+}
+
+// nodeEventValidator enforces that NODE events identify the node they
+// describe and, when a resource delta is attached, that every quantity in
+// it is present.
+type nodeEventValidator struct{}
+
+func (nodeEventValidator) Validate(event *si.EventRecord) error {
+	if event.ObjectID == "" {
+		return &ValidationError{Reason: "missing_object_id", Err: fmt.Errorf("NODE event is missing ObjectID")}
+	}
+	if event.Resource == nil {
+		return nil
+	}
+	for name, quantity := range event.Resource.Resources {
+		if quantity == nil {
+			return &ValidationError{
+				Reason: "malformed_resource_delta",
+				Err:    fmt.Errorf("NODE event resource delta %q has a nil quantity", name),
+			}
+		}
+	}
+	return nil
+}
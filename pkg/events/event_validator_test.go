@@ -0,0 +1,79 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+)
+
+func TestAddEventRejectsMissingRequestFields(t *testing.T) {
+	Init()
+	eventSystem, ok := GetEventSystem().(*EventSystemImpl)
+	if !ok {
+		t.Fatal("failed to cast GetEventSystem() to *EventSystemImpl")
+	}
+	eventSystem.StartService()
+	defer eventSystem.Stop()
+
+	err := eventSystem.AddEvent(&si.EventRecord{Type: si.EventRecord_REQUEST, ReferenceID: "app1"})
+	assert.ErrorContains(t, err, "missing ObjectID")
+
+	err = eventSystem.AddEvent(&si.EventRecord{Type: si.EventRecord_REQUEST, ObjectID: "alloc1"})
+	assert.ErrorContains(t, err, "missing ReferenceID")
+
+	err = eventSystem.AddEvent(&si.EventRecord{Type: si.EventRecord_REQUEST, ObjectID: "alloc1", ReferenceID: "app1"})
+	assert.NilError(t, err, "a well-formed REQUEST event should be accepted")
+}
+
+func TestAddEventPassesThroughUnregisteredTypes(t *testing.T) {
+	Init()
+	eventSystem, ok := GetEventSystem().(*EventSystemImpl)
+	if !ok {
+		t.Fatal("failed to cast GetEventSystem() to *EventSystemImpl")
+	}
+	eventSystem.StartService()
+	defer eventSystem.Stop()
+
+	err := eventSystem.AddEvent(&si.EventRecord{Type: si.EventRecord_APP})
+	assert.NilError(t, err, "APP events have no default validator and should pass through unchecked")
+}
+
+type alwaysRejectValidator struct{}
+
+func (alwaysRejectValidator) Validate(event *si.EventRecord) error {
+	return &ValidationError{Reason: "always_rejected", Err: fmt.Errorf("rejected by test validator")}
+}
+
+func TestRegisterValidatorOverridesDefault(t *testing.T) {
+	// Use a standalone instance rather than the Init() singleton so this
+	// registration cannot leak into other tests.
+	eventSystem := createEventSystem()
+	eventSystem.StartService()
+	defer eventSystem.Stop()
+
+	eventSystem.RegisterValidator(si.EventRecord_APP, alwaysRejectValidator{})
+
+	err := eventSystem.AddEvent(&si.EventRecord{Type: si.EventRecord_APP})
+	assert.ErrorContains(t, err, "rejected by test validator")
+}
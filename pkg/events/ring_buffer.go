@@ -0,0 +1,122 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+import (
+	"sync"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+)
+
+// eventRingBuffer is a fixed capacity, append-only buffer of si.EventRecord
+// indexed by a monotonically increasing ID. Once the buffer is full the
+// oldest entry is evicted to make room for the newest one.
+type eventRingBuffer struct {
+	sync.RWMutex
+	capacity uint64
+	events   []*si.EventRecord
+	lowestID uint64
+	nextID   uint64
+}
+
+func newEventRingBuffer(capacity uint64) *eventRingBuffer {
+	return &eventRingBuffer{
+		capacity: capacity,
+		events:   make([]*si.EventRecord, 0, capacity),
+	}
+}
+
+// Add appends an event to the buffer and returns the ID assigned to it.
+func (rb *eventRingBuffer) Add(event *si.EventRecord) uint64 {
+	rb.Lock()
+	defer rb.Unlock()
+
+	id := rb.nextID
+	rb.nextID++
+	rb.events = append(rb.events, event)
+	if uint64(len(rb.events)) > rb.capacity {
+		rb.events = rb.events[1:]
+		rb.lowestID++
+	}
+	return id
+}
+
+// GetEventsFromID returns up to count events starting at id, along with the
+// lowest and highest IDs currently held in the buffer.
+func (rb *eventRingBuffer) GetEventsFromID(id, count uint64) (collected []*si.EventRecord, lowest uint64, highest uint64) {
+	rb.RLock()
+	defer rb.RUnlock()
+
+	lowest = rb.lowestID
+	if rb.nextID > 0 {
+		highest = rb.nextID - 1
+	}
+	if len(rb.events) == 0 {
+		return nil, lowest, highest
+	}
+	if id < lowest {
+		id = lowest
+	}
+	startIdx := id - lowest
+	if startIdx >= uint64(len(rb.events)) {
+		return nil, lowest, highest
+	}
+	endIdx := startIdx + count
+	if endIdx > uint64(len(rb.events)) {
+		endIdx = uint64(len(rb.events))
+	}
+	return rb.events[startIdx:endIdx], lowest, highest
+}
+
+// seed restores a previously persisted event at its original id, used only
+// during durable log replay. Callers must invoke it in increasing id order.
+// An id below the next id of a non-empty buffer is already held and is
+// skipped, so replaying the log again after a Stop()/StartService() cycle
+// does not duplicate events.
+func (rb *eventRingBuffer) seed(id uint64, event *si.EventRecord) {
+	rb.Lock()
+	defer rb.Unlock()
+
+	if len(rb.events) > 0 && id < rb.nextID {
+		return
+	}
+	if len(rb.events) == 0 {
+		rb.lowestID = id
+	}
+	rb.events = append(rb.events, event)
+	rb.nextID = id + 1
+	if uint64(len(rb.events)) > rb.capacity {
+		rb.events = rb.events[1:]
+		rb.lowestID++
+	}
+}
+
+// Resize changes the capacity of the buffer, trimming the oldest entries if
+// the new capacity is smaller than the current content.
+func (rb *eventRingBuffer) Resize(capacity uint64) {
+	rb.Lock()
+	defer rb.Unlock()
+
+	rb.capacity = capacity
+	if uint64(len(rb.events)) > capacity {
+		evicted := uint64(len(rb.events)) - capacity
+		rb.events = rb.events[evicted:]
+		rb.lowestID += evicted
+	}
+}
@@ -0,0 +1,130 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package webservice
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/apache/yunikorn-core/pkg/events"
+	"github.com/apache/yunikorn-core/pkg/log"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+)
+
+const (
+	defaultEventsBatchCount = 1000
+	headerAcceptEncoding    = "Accept-Encoding"
+	headerContentEncoding   = "Content-Encoding"
+	headerEventLowestID     = "X-Event-Lowest-Id"
+	headerEventHighestID    = "X-Event-Highest-Id"
+)
+
+// eventsBatchResponse is the uncompressed JSON shape returned when the
+// client does not advertise support for any of the codecs GetEventsHandler
+// understands.
+type eventsBatchResponse struct {
+	Records   []*si.EventRecord `json:"records"`
+	LowestID  uint64            `json:"lowestId"`
+	HighestID uint64            `json:"highestId"`
+}
+
+// GetEventsHandler returns a batch of event records starting at the
+// "startId" query parameter (default 0), up to "count" records (default
+// defaultEventsBatchCount). When the request's Accept-Encoding header
+// advertises gzip or zstd, the response is served pre-compressed via
+// EventSystem.GetEventsFromIDCompressed; otherwise it falls back to the
+// plain JSON path via GetEventsFromID unchanged.
+func GetEventsHandler(eventSystem events.EventSystem) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startID, count, err := parseEventsQuery(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		codec := negotiateCodec(r.Header.Get(headerAcceptEncoding))
+		if codec == events.CodecNone {
+			writeUncompressedEvents(w, eventSystem, startID, count)
+			return
+		}
+
+		payload, lowest, highest, err := eventSystem.GetEventsFromIDCompressed(startID, count, codec)
+		if err != nil {
+			log.Log(log.SchedFSM).Error("failed to retrieve compressed event batch")
+			http.Error(w, "failed to retrieve events", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set(headerContentEncoding, codec.String())
+		w.Header().Set(headerEventLowestID, strconv.FormatUint(lowest, 10))
+		w.Header().Set(headerEventHighestID, strconv.FormatUint(highest, 10))
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(payload)
+	}
+}
+
+// negotiateCodec picks a codec GetEventsHandler supports out of a
+// comma-separated Accept-Encoding header, preferring zstd over gzip when a
+// client advertises both. CodecNone is returned when neither is present,
+// preserving the original uncompressed behavior for older clients.
+func negotiateCodec(acceptEncoding string) events.Codec {
+	codec := events.CodecNone
+	for _, token := range strings.Split(acceptEncoding, ",") {
+		switch events.ParseCodec(strings.TrimSpace(token)) {
+		case events.CodecZstd:
+			return events.CodecZstd
+		case events.CodecGzip:
+			codec = events.CodecGzip
+		}
+	}
+	return codec
+}
+
+func parseEventsQuery(r *http.Request) (startID uint64, count uint64, err error) {
+	startID = 0
+	if raw := r.URL.Query().Get("startId"); raw != "" {
+		startID, err = strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	count = defaultEventsBatchCount
+	if raw := r.URL.Query().Get("count"); raw != "" {
+		count, err = strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return startID, count, nil
+}
+
+func writeUncompressedEvents(w http.ResponseWriter, eventSystem events.EventSystem, startID uint64, count uint64) {
+	records, lowest, highest := eventSystem.GetEventsFromID(startID, count)
+	w.Header().Set(headerEventLowestID, strconv.FormatUint(lowest, 10))
+	w.Header().Set(headerEventHighestID, strconv.FormatUint(highest, 10))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(eventsBatchResponse{
+		Records:   records,
+		LowestID:  lowest,
+		HighestID: highest,
+	})
+}
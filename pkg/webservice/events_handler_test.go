@@ -0,0 +1,119 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package webservice
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/apache/yunikorn-core/pkg/common"
+	"github.com/apache/yunikorn-core/pkg/events"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+)
+
+// addEvents adds n REQUEST events to the process-wide singleton EventSystem
+// and returns the highest ID assigned, once all of them have been
+// processed. Tests share the singleton (events.EventSystemImpl has no
+// exported constructor outside the events package), so every test advances
+// from wherever the previous one left off instead of assuming IDs start
+// at 0.
+func addEvents(t *testing.T, eventSystem events.EventSystem, n int) uint64 {
+	t.Helper()
+	existing, _, before := eventSystem.GetEventsFromID(0, 1)
+	target := before + uint64(n)
+	if len(existing) == 0 {
+		target = uint64(n) - 1
+	}
+	for i := 0; i < n; i++ {
+		err := eventSystem.AddEvent(&si.EventRecord{
+			Type:        si.EventRecord_REQUEST,
+			ObjectID:    "alloc1",
+			ReferenceID: "app1",
+			Message:     strconv.Itoa(i),
+		})
+		assert.NilError(t, err)
+	}
+	var highest uint64
+	err := common.WaitForCondition(time.Millisecond, time.Second, func() bool {
+		_, _, highest = eventSystem.GetEventsFromID(0, 1)
+		return highest >= target
+	})
+	assert.NilError(t, err, "events should have been processed")
+	return highest
+}
+
+func TestGetEventsHandler(t *testing.T) {
+	events.Init()
+	eventSystem := events.GetEventSystem()
+	eventSystem.StartService()
+	t.Cleanup(eventSystem.Stop)
+
+	handler := GetEventsHandler(eventSystem)
+
+	t.Run("falls back to JSON without Accept-Encoding", func(t *testing.T) {
+		highest := addEvents(t, eventSystem, 5)
+		startID := highest - 4
+
+		req := httptest.NewRequest(http.MethodGet, "/ws/v1/events?startId="+strconv.FormatUint(startID, 10)+"&count=5", nil)
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "", rec.Header().Get(headerContentEncoding))
+	})
+
+	t.Run("honors Accept-Encoding: gzip", func(t *testing.T) {
+		highest := addEvents(t, eventSystem, 5)
+		startID := highest - 4
+
+		req := httptest.NewRequest(http.MethodGet, "/ws/v1/events?startId="+strconv.FormatUint(startID, 10)+"&count=5", nil)
+		req.Header.Set(headerAcceptEncoding, "gzip")
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "gzip", rec.Header().Get(headerContentEncoding))
+
+		records, err := events.DecodeEventsCompressed(rec.Body.Bytes(), events.CodecGzip)
+		assert.NilError(t, err)
+		assert.Equal(t, 5, len(records))
+	})
+
+	t.Run("prefers zstd over gzip when both are advertised", func(t *testing.T) {
+		highest := addEvents(t, eventSystem, 3)
+		startID := highest - 2
+
+		req := httptest.NewRequest(http.MethodGet, "/ws/v1/events?startId="+strconv.FormatUint(startID, 10)+"&count=3", nil)
+		req.Header.Set(headerAcceptEncoding, "gzip, zstd")
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+
+		assert.Equal(t, "zstd", rec.Header().Get(headerContentEncoding))
+
+		records, err := events.DecodeEventsCompressed(rec.Body.Bytes(), events.CodecZstd)
+		assert.NilError(t, err)
+		assert.Equal(t, 3, len(records))
+	})
+}